@@ -0,0 +1,103 @@
+package mackerel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+const (
+	defaultBaseURL   = "https://api.mackerelio.com/"
+	defaultUserAgent = "mackerel-client-go"
+)
+
+// Client represents a client for the Mackerel API.
+type Client struct {
+	BaseURL    *url.URL
+	APIKey     string
+	UserAgent  string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a new Client for the given Mackerel API key, pointed at
+// the default Mackerel API endpoint.
+func NewClient(apiKey string) *Client {
+	u, _ := url.Parse(defaultBaseURL)
+	return &Client{
+		BaseURL:    u,
+		APIKey:     apiKey,
+		UserAgent:  defaultUserAgent,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// urlFor builds the full request URL for path against the client's BaseURL.
+func (c *Client) urlFor(path string) *url.URL {
+	u := *c.BaseURL
+	u.Path = path
+	return &u
+}
+
+// Request performs req, attaching the client's authentication and
+// user-agent headers. If req was built with http.NewRequestWithContext, that
+// context governs cancellation of the underlying HTTP round trip.
+func (c *Client) Request(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Api-Key", c.APIKey)
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer closeResponse(resp)
+		return nil, fmt.Errorf("mackerel: API request to %s failed: %s", req.URL, resp.Status)
+	}
+	return resp, nil
+}
+
+// PostJSON sends payload as a JSON-encoded POST request to path. The context
+// governs the lifetime of the underlying HTTP request; cancelling it cancels
+// the request in flight.
+func (c *Client) PostJSON(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	return c.requestJSON(ctx, http.MethodPost, path, payload)
+}
+
+// PutJSON sends payload as a JSON-encoded PUT request to path. The context
+// governs the lifetime of the underlying HTTP request; cancelling it cancels
+// the request in flight.
+func (c *Client) PutJSON(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	return c.requestJSON(ctx, http.MethodPut, path, payload)
+}
+
+// requestJSON builds and sends a JSON-bodied HTTP request of the given
+// method to path. It is shared by PostJSON and PutJSON.
+func (c *Client) requestJSON(ctx context.Context, method, path string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.urlFor(path).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.Request(req)
+}
+
+// closeResponse drains and closes resp.Body so the underlying connection can
+// be reused. It tolerates a nil resp so callers can defer it unconditionally.
+func closeResponse(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,136 @@
+package mackerel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func float64p(f float64) *float64 { return &f }
+
+func TestMonitorHostMetricJSONRoundTrip(t *testing.T) {
+	until := time.Unix(1700000000, 0)
+	want := &MonitorHostMetric{
+		Name:     "disk.writes",
+		Type:     monitorTypeHostMeric,
+		Metric:   "disk.writes.delta",
+		Operator: MonitorOperatorGreaterThan,
+		Thresholds: Thresholds{
+			Warning:  float64p(100),
+			Critical: float64p(200),
+		},
+		Duration: 3,
+		NotificationOptions: NotificationOptions{
+			SilencedUntil: map[string]time.Time{"*": until},
+		},
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if data["warning"] != 100.0 || data["critical"] != 200.0 {
+		t.Errorf("Thresholds not flattened to top-level warning/critical fields: %v", data)
+	}
+	silenced, ok := data["silencedUntil"].(map[string]interface{})
+	if !ok || silenced["*"] != float64(until.Unix()) {
+		t.Errorf("SilencedUntil not serialized as Unix seconds: %v", data)
+	}
+
+	var got MonitorHostMetric
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Thresholds.Warning == nil || *got.Thresholds.Warning != 100 {
+		t.Errorf("Thresholds.Warning round-trip = %v, want 100", got.Thresholds.Warning)
+	}
+	if got.Thresholds.Critical == nil || *got.Thresholds.Critical != 200 {
+		t.Errorf("Thresholds.Critical round-trip = %v, want 200", got.Thresholds.Critical)
+	}
+	if !got.SilencedUntil["*"].Equal(until) {
+		t.Errorf("SilencedUntil round-trip = %v, want %v", got.SilencedUntil["*"], until)
+	}
+}
+
+func TestDecodeMonitorUnknownRoundTrip(t *testing.T) {
+	const raw = `{"id":"abc","type":"anomalyDetection","name":"future monitor","someNewField":42}`
+
+	m, err := DecodeMonitor([]byte(raw))
+	if err != nil {
+		t.Fatalf("DecodeMonitor() error = %v", err)
+	}
+	unknown, ok := m.(*MonitorUnknown)
+	if !ok {
+		t.Fatalf("DecodeMonitor() = %T, want *MonitorUnknown", m)
+	}
+	if unknown.MonitorID() != "abc" || unknown.MonitorName() != "future monitor" || unknown.MonitorType() != "anomalyDetection" {
+		t.Errorf("MonitorUnknown fields = %+v, want id/name/type parsed from raw JSON", unknown)
+	}
+
+	b, err := json.Marshal(unknown)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(b) != raw {
+		t.Errorf("Marshal() = %s, want byte-identical round-trip of %s", b, raw)
+	}
+}
+
+func TestMonitorOperatorRejectsUnknownValue(t *testing.T) {
+	var op MonitorOperator
+	if err := json.Unmarshal([]byte(`"=="`), &op); err == nil {
+		t.Error("UnmarshalJSON() error = nil, want rejection of an unsupported operator")
+	}
+}
+
+func TestMuteMonitorReadModifyWrites(t *testing.T) {
+	until := time.Unix(1700000000, 0)
+	var gotMethod, gotPath string
+	var gotBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v0/monitors":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"monitors":[{"id":"abc","type":"connectivity","name":"net"}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v0/monitors/abc":
+			gotMethod, gotPath = r.Method, r.URL.Path
+			if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"abc","type":"connectivity","name":"net"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	client := NewClient("dummy-key")
+	client.BaseURL = u
+
+	if err := client.MuteMonitor(context.Background(), "abc", until, nil); err != nil {
+		t.Fatalf("MuteMonitor() error = %v", err)
+	}
+
+	if gotMethod != http.MethodPut || gotPath != "/api/v0/monitors/abc" {
+		t.Errorf("MuteMonitor() sent %s %s, want a PUT to /api/v0/monitors/abc", gotMethod, gotPath)
+	}
+	silenced, ok := gotBody["silencedUntil"].(map[string]interface{})
+	if !ok || silenced["*"] != float64(until.Unix()) {
+		t.Errorf("PUT body silencedUntil = %v, want {\"*\": %d}", gotBody["silencedUntil"], until.Unix())
+	}
+}
@@ -0,0 +1,336 @@
+// Package monitorsync reconciles a declarative, user-maintained list of
+// monitors against what is actually registered on Mackerel, in the same
+// plan/apply style as infrastructure-as-code tools: Plan computes a diff
+// without touching anything, and Apply executes that diff.
+package monitorsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	mackerel "github.com/mackerelio/mackerel-client-go"
+	"sigs.k8s.io/yaml"
+)
+
+// KeyFunc extracts the stable, user-assigned key used to match a desired
+// Monitor against one already registered on Mackerel. The zero value of
+// Options uses keyByName, which matches on Monitor.MonitorName.
+type KeyFunc func(mackerel.Monitor) string
+
+// keyByName is the default KeyFunc: monitors are matched by their Name.
+func keyByName(m mackerel.Monitor) string { return m.MonitorName() }
+
+// Options configures Plan and Apply.
+type Options struct {
+	// KeyFunc matches desired monitors against existing ones. Defaults to
+	// keyByName.
+	KeyFunc KeyFunc
+}
+
+func (o *Options) keyFunc() KeyFunc {
+	if o != nil && o.KeyFunc != nil {
+		return o.KeyFunc
+	}
+	return keyByName
+}
+
+// FieldDiff describes a single field that differs between the current and
+// desired state of a matched monitor.
+type FieldDiff struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// Update describes a monitor that exists both on Mackerel and in the desired
+// set, but whose fields differ.
+type Update struct {
+	Key     string
+	Current mackerel.Monitor
+	Desired mackerel.Monitor
+	Fields  []FieldDiff
+}
+
+// PlanResult is the diff between the desired monitor set and what is
+// currently registered on Mackerel, as computed by Plan.
+type PlanResult struct {
+	Creates []mackerel.Monitor
+	Updates []Update
+	Deletes []mackerel.Monitor
+	NoOps   []mackerel.Monitor
+}
+
+// Plan fetches the monitors currently registered on Mackerel, matches them
+// against desired by key, and returns the changes needed to reconcile the
+// two. Plan performs no writes. A nil opts is equivalent to &Options{}.
+//
+// Limitation: a plain (non-pointer) field left at its zero value in a
+// desired Monitor — an empty Memo, IsMute left false, a nil Scopes slice —
+// is read as "no opinion", not "set this to zero". Plan can never drive such
+// a field back to zero this way; to clear one, set the full Monitor's
+// non-zero fields deliberately rather than omitting the field. Pointer
+// fields (Thresholds.Warning, NotificationOptions.RenotifyIntervalMinutes,
+// etc.) don't have this problem: nil means "no opinion" and a non-nil
+// pointer, even one pointing at zero, is always compared and applied. See
+// diffMonitor for the comparison this is built on.
+func Plan(ctx context.Context, client *mackerel.Client, desired []mackerel.Monitor, opts *Options) (*PlanResult, error) {
+	current, err := client.FindMonitorsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("monitorsync: finding current monitors: %w", err)
+	}
+
+	keyFunc := opts.keyFunc()
+
+	currentByKey := make(map[string]mackerel.Monitor, len(current))
+	for _, m := range current {
+		currentByKey[keyFunc(m)] = m
+	}
+
+	result := &PlanResult{}
+	seen := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		key := keyFunc(d)
+		seen[key] = true
+
+		cur, ok := currentByKey[key]
+		if !ok {
+			result.Creates = append(result.Creates, d)
+			continue
+		}
+		if fields := diffMonitor(cur, d); len(fields) > 0 {
+			result.Updates = append(result.Updates, Update{Key: key, Current: cur, Desired: d, Fields: fields})
+		} else {
+			result.NoOps = append(result.NoOps, cur)
+		}
+	}
+	for key, m := range currentByKey {
+		if !seen[key] {
+			result.Deletes = append(result.Deletes, m)
+		}
+	}
+	return result, nil
+}
+
+// ignoredDiffFields lists struct fields that are populated by the server and
+// must never, on their own, cause an update.
+var ignoredDiffFields = map[string]bool{
+	"ID": true,
+}
+
+// timeType lets diffStruct recognize a time.Time field as a leaf value
+// rather than a struct to recurse into.
+var timeType = reflect.TypeOf(time.Time{})
+
+// diffMonitor compares cur against desired field by field using reflection,
+// skipping fields in ignoredDiffFields outright. See the "Limitation" note
+// on Plan for how zero-valued plain fields are handled; pointer fields are
+// always compared by diffStruct, zero or not.
+func diffMonitor(cur, desired mackerel.Monitor) []FieldDiff {
+	if cur.MonitorType() != desired.MonitorType() {
+		return []FieldDiff{{Field: "Type", Before: cur.MonitorType(), After: desired.MonitorType()}}
+	}
+
+	cv := reflect.Indirect(reflect.ValueOf(cur))
+	dv := reflect.Indirect(reflect.ValueOf(desired))
+	if cv.Type() != dv.Type() {
+		return []FieldDiff{{Field: "Type", Before: cv.Type().String(), After: dv.Type().String()}}
+	}
+
+	return diffStruct("", cv, dv)
+}
+
+// diffStruct walks cv/dv's fields, descending into nested structs (e.g.
+// Thresholds, NotificationOptions) so that pointer fields inside them are
+// each judged on their own nil-ness rather than as one opaque blob. field
+// names in a nested struct are reported dotted, e.g. "Thresholds.Warning".
+func diffStruct(prefix string, cv, dv reflect.Value) []FieldDiff {
+	var diffs []FieldDiff
+	t := cv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		if ignoredDiffFields[name] {
+			continue
+		}
+		field := prefix + name
+		cf, df := cv.Field(i), dv.Field(i)
+
+		switch {
+		case df.Kind() == reflect.Ptr:
+			if df.IsNil() {
+				continue // no opinion
+			}
+			if cf.IsNil() || !reflect.DeepEqual(cf.Interface(), df.Interface()) {
+				diffs = append(diffs, FieldDiff{Field: field, Before: derefOrNil(cf), After: derefOrNil(df)})
+			}
+		case df.Kind() == reflect.Struct && df.Type() != timeType:
+			diffs = append(diffs, diffStruct(field+".", cf, df)...)
+		default:
+			if df.IsZero() {
+				continue // no opinion
+			}
+			if !reflect.DeepEqual(cf.Interface(), df.Interface()) {
+				diffs = append(diffs, FieldDiff{Field: field, Before: cf.Interface(), After: df.Interface()})
+			}
+		}
+	}
+	return diffs
+}
+
+// derefOrNil returns v's pointee, or nil if v is a nil pointer.
+func derefOrNil(v reflect.Value) interface{} {
+	if v.IsNil() {
+		return nil
+	}
+	return v.Elem().Interface()
+}
+
+// ApplyOptions configures how Apply executes a Plan.
+type ApplyOptions struct {
+	// DryRun reports what Apply would do without performing any writes.
+	DryRun bool
+	// ContinueOnError keeps applying the remaining plan items after one
+	// fails, instead of stopping at the first error.
+	ContinueOnError bool
+	// MaxDeletePercent refuses to run a plan whose Deletes would remove more
+	// than this percentage of the monitors currently registered, unless
+	// Force is set. Zero means no limit.
+	MaxDeletePercent float64
+	// Force bypasses the MaxDeletePercent safety check.
+	Force bool
+}
+
+// ApplyResult records the outcome of executing a Plan.
+type ApplyResult struct {
+	Created []mackerel.Monitor
+	Updated []mackerel.Monitor
+	Deleted []mackerel.Monitor
+	Errors  []error
+}
+
+// Apply executes plan against client in create, then update, then delete
+// order, so a monitor being renamed (a delete of the old key alongside a
+// create of the new one) never leaves a gap in coverage. A nil opts is
+// equivalent to &ApplyOptions{}.
+func Apply(ctx context.Context, client *mackerel.Client, plan *PlanResult, opts *ApplyOptions) (*ApplyResult, error) {
+	if opts == nil {
+		opts = &ApplyOptions{}
+	}
+
+	if !opts.Force && opts.MaxDeletePercent > 0 {
+		total := len(plan.Updates) + len(plan.Deletes) + len(plan.NoOps)
+		if total > 0 {
+			deletePercent := float64(len(plan.Deletes)) / float64(total) * 100
+			if deletePercent > opts.MaxDeletePercent {
+				return nil, fmt.Errorf("monitorsync: plan deletes %.1f%% of monitors, exceeding the %.1f%% safety threshold; re-run with Force to proceed", deletePercent, opts.MaxDeletePercent)
+			}
+		}
+	}
+
+	result := &ApplyResult{}
+	// fail records err and, unless the caller asked to keep going, returns it
+	// so the enclosing loop can stop and propagate it.
+	fail := func(err error) error {
+		result.Errors = append(result.Errors, err)
+		if !opts.ContinueOnError {
+			return err
+		}
+		return nil
+	}
+
+	for _, m := range plan.Creates {
+		if opts.DryRun {
+			result.Created = append(result.Created, m)
+			continue
+		}
+		created, err := client.CreateMonitorContext(ctx, m)
+		if err != nil {
+			if err := fail(fmt.Errorf("monitorsync: creating monitor %q: %w", m.MonitorName(), err)); err != nil {
+				return result, err
+			}
+			continue
+		}
+		result.Created = append(result.Created, created)
+	}
+
+	for _, u := range plan.Updates {
+		if opts.DryRun {
+			result.Updated = append(result.Updated, u.Desired)
+			continue
+		}
+		updated, err := client.UpdateMonitorContext(ctx, u.Current.MonitorID(), u.Desired)
+		if err != nil {
+			if err := fail(fmt.Errorf("monitorsync: updating monitor %q: %w", u.Key, err)); err != nil {
+				return result, err
+			}
+			continue
+		}
+		result.Updated = append(result.Updated, updated)
+	}
+
+	for _, m := range plan.Deletes {
+		if opts.DryRun {
+			result.Deleted = append(result.Deleted, m)
+			continue
+		}
+		deleted, err := client.DeleteMonitorContext(ctx, m.MonitorID())
+		if err != nil {
+			if err := fail(fmt.Errorf("monitorsync: deleting monitor %q: %w", m.MonitorName(), err)); err != nil {
+				return result, err
+			}
+			continue
+		}
+		result.Deleted = append(result.Deleted, deleted)
+	}
+
+	return result, nil
+}
+
+// Marshal serializes a heterogeneous list of monitors to YAML. Each element
+// is written as the same JSON-tagged object the Mackerel API speaks,
+// including its "type" discriminator, so Unmarshal can later decode it back
+// into the same concrete Go type via mackerel.RegisterMonitorType.
+func Marshal(monitors []mackerel.Monitor) ([]byte, error) {
+	raw := make([]json.RawMessage, 0, len(monitors))
+	for _, m := range monitors {
+		b, err := json.Marshal(m)
+		if err != nil {
+			return nil, fmt.Errorf("monitorsync: marshaling monitor %q: %w", m.MonitorName(), err)
+		}
+		// The struct's own Type field may be unset, in which case its
+		// omitempty tag drops "type"; fill it in from MonitorType.
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return nil, fmt.Errorf("monitorsync: marshaling monitor %q: %w", m.MonitorName(), err)
+		}
+		if _, ok := obj["type"]; !ok && obj != nil {
+			obj["type"], _ = json.Marshal(m.MonitorType())
+			if b, err = json.Marshal(obj); err != nil {
+				return nil, fmt.Errorf("monitorsync: marshaling monitor %q: %w", m.MonitorName(), err)
+			}
+		}
+		raw = append(raw, b)
+	}
+	return yaml.Marshal(raw)
+}
+
+// Unmarshal parses YAML (or JSON, a subset of YAML) produced by Marshal back
+// into a list of monitors, dispatching each element to its concrete Go type
+// by its "type" field via mackerel.DecodeMonitor.
+func Unmarshal(b []byte) ([]mackerel.Monitor, error) {
+	var raw []json.RawMessage
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("monitorsync: parsing monitor list: %w", err)
+	}
+	monitors := make([]mackerel.Monitor, 0, len(raw))
+	for _, d := range raw {
+		m, err := mackerel.DecodeMonitor(d)
+		if err != nil {
+			return nil, fmt.Errorf("monitorsync: decoding monitor: %w", err)
+		}
+		monitors = append(monitors, m)
+	}
+	return monitors, nil
+}
@@ -0,0 +1,114 @@
+package monitorsync
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	mackerel "github.com/mackerelio/mackerel-client-go"
+)
+
+func newTestClient(t *testing.T, body string) *mackerel.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v0/monitors" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	client := mackerel.NewClient("dummy-key")
+	client.BaseURL = u
+	return client
+}
+
+func TestPlan(t *testing.T) {
+	const body = `{"monitors":[
+		{"id":"1","type":"connectivity","name":"keep"},
+		{"id":"2","type":"connectivity","name":"remove-me"},
+		{"id":"3","type":"connectivity","name":"update-me","memo":"old"}
+	]}`
+	client := newTestClient(t, body)
+
+	desired := []mackerel.Monitor{
+		&mackerel.MonitorConnectivity{Name: "keep"},
+		&mackerel.MonitorConnectivity{Name: "update-me", Memo: "new"},
+		&mackerel.MonitorConnectivity{Name: "create-me"},
+	}
+
+	plan, err := Plan(context.Background(), client, desired, nil)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(plan.Creates) != 1 || plan.Creates[0].MonitorName() != "create-me" {
+		t.Errorf("Creates = %+v, want [create-me]", plan.Creates)
+	}
+	if len(plan.Updates) != 1 || plan.Updates[0].Key != "update-me" {
+		t.Errorf("Updates = %+v, want [update-me]", plan.Updates)
+	}
+	if len(plan.Deletes) != 1 || plan.Deletes[0].MonitorName() != "remove-me" {
+		t.Errorf("Deletes = %+v, want [remove-me]", plan.Deletes)
+	}
+	if len(plan.NoOps) != 1 || plan.NoOps[0].MonitorName() != "keep" {
+		t.Errorf("NoOps = %+v, want [keep]", plan.NoOps)
+	}
+}
+
+func TestApplyRefusesExceedingMaxDeletePercent(t *testing.T) {
+	plan := &PlanResult{
+		Deletes: []mackerel.Monitor{&mackerel.MonitorConnectivity{ID: "1", Name: "a"}},
+		NoOps:   []mackerel.Monitor{&mackerel.MonitorConnectivity{ID: "2", Name: "b"}},
+	}
+
+	if _, err := Apply(context.Background(), nil, plan, &ApplyOptions{MaxDeletePercent: 10}); err == nil {
+		t.Error("Apply() error = nil, want refusal for a plan deleting 50% of monitors against a 10% threshold")
+	}
+}
+
+func TestDiffMonitorComparesZeroPointerFields(t *testing.T) {
+	zero := 0.0
+	cur := &mackerel.MonitorHostMetric{
+		Name:       "disk",
+		Metric:     "disk.writes",
+		Duration:   1,
+		Thresholds: mackerel.Thresholds{Warning: float64p(10)},
+	}
+	desired := &mackerel.MonitorHostMetric{
+		Name:       "disk",
+		Metric:     "disk.writes",
+		Duration:   1,
+		Thresholds: mackerel.Thresholds{Warning: &zero},
+	}
+
+	fields := diffMonitor(cur, desired)
+	if len(fields) != 1 || fields[0].Field != "Thresholds.Warning" {
+		t.Errorf("diffMonitor() = %+v, want a single Thresholds.Warning diff even though the desired pointer targets zero", fields)
+	}
+}
+
+func float64p(f float64) *float64 { return &f }
+
+func TestApplyDryRunSkipsWrites(t *testing.T) {
+	plan := &PlanResult{
+		Creates: []mackerel.Monitor{&mackerel.MonitorConnectivity{Name: "create-me"}},
+		Deletes: []mackerel.Monitor{&mackerel.MonitorConnectivity{ID: "1", Name: "remove-me"}},
+	}
+
+	// client is nil: a DryRun Apply must never touch it.
+	result, err := Apply(context.Background(), nil, plan, &ApplyOptions{DryRun: true, Force: true})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(result.Created) != 1 || len(result.Deleted) != 1 {
+		t.Errorf("Apply() result = %+v, want 1 created and 1 deleted", result)
+	}
+}